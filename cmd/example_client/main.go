@@ -7,9 +7,9 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"text/template"
 	"time"
 
+	"github.com/gosuda/relaydns/internal/backend"
 	"github.com/gosuda/relaydns/relaydns"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -34,6 +34,24 @@ var (
 	flagPreferQUIC     bool
 	flagPreferLocal    bool
 	flagHTTPTimeout    time.Duration
+	flagAdminHTTP      string
+	flagCacheTTL       time.Duration
+	flagCacheNegTTL    time.Duration
+	flagCachePath      string
+	flagPreferGeo      bool
+	flagGeoIPDB        string
+	flagSelfCoords     string
+	flagPublicIPSvc    string
+	flagGRPCEndpoint   string
+	flagMetricsAddr    string
+	flagPprof          bool
+
+	flagBackendMode         string
+	flagBackendDir          string
+	flagBackendIndex        string
+	flagBackendListDir      bool
+	flagBackendUpstream     string
+	flagBackendProxyTimeout time.Duration
 )
 
 func init() {
@@ -50,6 +68,25 @@ func init() {
 	flags.BoolVar(&flagPreferQUIC, "prefer-quic", true, "prefer QUIC multiaddrs when available")
 	flags.BoolVar(&flagPreferLocal, "prefer-local", true, "prefer loopback/local multiaddrs when available")
 	flags.DurationVar(&flagHTTPTimeout, "http-timeout", 3*time.Second, "timeout for server /health fetch")
+	flags.StringVar(&flagAdminHTTP, "admin-http", "127.0.0.1:8082", "local admin HTTP listen address for the status endpoint (empty disables it)")
+	flags.DurationVar(&flagCacheTTL, "cache-ttl", 5*time.Minute, "positive TTL for the provider/backend cache")
+	flags.DurationVar(&flagCacheNegTTL, "cache-negative-ttl", 30*time.Second, "negative TTL for peers that failed to resolve")
+	flags.StringVar(&flagCachePath, "cache-path", "", "optional file to persist the provider cache across restarts (empty disables persistence)")
+	flags.BoolVar(&flagPreferGeo, "prefer-geo", false, "rank bootstraps by geographic proximity using --geoip-db")
+	flags.StringVar(&flagGeoIPDB, "geoip-db", "", "path to a MaxMind GeoLite2 City mmdb, required for --prefer-geo")
+	flags.StringVar(&flagSelfCoords, "self-coords", "", "override self location as \"lat,lon\" instead of geolocating the public IP")
+	flags.StringVar(&flagPublicIPSvc, "public-ip-service", "https://api.ipify.org", "third-party HTTP GET endpoint used to discover the local public IP for --prefer-geo when --self-coords isn't set (empty disables the lookup)")
+	flags.StringVar(&flagGRPCEndpoint, "grpc-endpoint", "", "relayserver gRPC control-plane address (empty disables it, falling back to pubsub adverts only)")
+	flags.StringVar(&flagMetricsAddr, "metrics-addr", "", "Prometheus /metrics listen address (empty disables metrics)")
+	flags.BoolVar(&flagPprof, "pprof", false, "mount net/http/pprof on --metrics-addr")
+	flags.StringVar(&flagBackendMode, "backend-mode", "demo", "local backend mode: demo, static, or proxy")
+	flags.StringVar(&flagBackendDir, "backend-dir", "", "directory to serve (static mode)")
+	flags.StringVar(&flagBackendIndex, "index", "index.html", "index file name for directory requests (static mode)")
+	flags.BoolVar(&flagBackendListDir, "backend-list-dir", false, "allow directory listings (static mode)")
+	flags.StringVar(&flagBackendUpstream, "backend-upstream", "", "upstream base URL to reverse-proxy to (proxy mode)")
+	flags.DurationVar(&flagBackendProxyTimeout, "backend-proxy-timeout", 30*time.Second, "response header timeout for the upstream (proxy mode)")
+
+	rootCmd.AddCommand(statusCmd)
 }
 
 func main() {
@@ -62,34 +99,7 @@ func runClient(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// 1) HTTP backend
-	go func() {
-		mux := http.NewServeMux()
-		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-			data := struct {
-				Now  string
-				Host string
-				Addr string
-			}{
-				Now:  time.Now().Format(time.RFC1123),
-				Host: r.Host,
-				Addr: flagBackendHTTP,
-			}
-			_ = pageTmpl.Execute(w, data)
-		})
-		mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("ok"))
-		})
-
-		log.Info().Msgf("[client] local backend http %s", flagBackendHTTP)
-		if err := http.ListenAndServe(flagBackendHTTP, mux); err != nil {
-			log.Error().Err(err).Msg("[client] http backend error")
-			cancel()
-		}
-	}()
-
-	// 2) libp2p host
+	// 1) libp2p host
 	h, err := relaydns.MakeHost(ctx, 0, flagRelay)
 	if err != nil {
 		return fmt.Errorf("make host: %w", err)
@@ -108,12 +118,53 @@ func runClient(cmd *cobra.Command, args []string) error {
 		HTTPTimeout: flagHTTPTimeout,
 		PreferQUIC:  flagPreferQUIC,
 		PreferLocal: flagPreferLocal,
+
+		AdminHTTP: flagAdminHTTP,
+
+		CacheTTL:         flagCacheTTL,
+		CacheNegativeTTL: flagCacheNegTTL,
+		CachePath:        flagCachePath,
+
+		PreferGeo:       flagPreferGeo,
+		GeoIPDB:         flagGeoIPDB,
+		SelfCoords:      flagSelfCoords,
+		PublicIPService: flagPublicIPSvc,
+
+		GRPCEndpoint: flagGRPCEndpoint,
+
+		MetricsAddr: flagMetricsAddr,
+		Pprof:       flagPprof,
 	})
 	if err != nil {
 		return fmt.Errorf("new client: %w", err)
 	}
 	defer client.Close()
 
+	// 2) HTTP backend, instrumented against the client's metrics registry
+	be, err := backend.New(backend.Config{
+		Mode:       flagBackendMode,
+		ListenAddr: flagBackendHTTP,
+		Registry:   client.Metrics.Registry,
+
+		Dir:     flagBackendDir,
+		Index:   flagBackendIndex,
+		ListDir: flagBackendListDir,
+
+		Upstream:     flagBackendUpstream,
+		ProxyTimeout: flagBackendProxyTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("new backend: %w", err)
+	}
+
+	go func() {
+		log.Info().Msgf("[client] local backend (%s) http %s", be.Name(), flagBackendHTTP)
+		if err := http.ListenAndServe(flagBackendHTTP, be.Handler()); err != nil {
+			log.Error().Err(err).Msg("[client] http backend error")
+			cancel()
+		}
+	}()
+
 	if addrs := h.Addrs(); len(addrs) > 0 {
 		for _, a := range addrs {
 			log.Info().Msgf("[client] host addr: %s/p2p/%s", a.String(), h.ID().String())
@@ -137,26 +188,3 @@ func addrToTarget(listen string) string {
 	}
 	return listen
 }
-
-var pageTmpl = template.Must(template.New("index").Parse(`<!DOCTYPE html>
-<html lang="en">
-<head>
-	<meta charset="UTF-8">
-	<title>RelayDNS Backend</title>
-	<style>
-		body { font-family: sans-serif; background: #f9f9f9; padding: 40px; }
-		h1 { color: #333; }
-		footer { margin-top: 40px; color: #666; font-size: 0.9em; }
-		.card { background: white; border-radius: 12px; padding: 24px; box-shadow: 0 2px 6px rgba(0,0,0,0.1); }
-	</style>
-</head>
-<body>
-	<div class="card">
-		<h1>🚀 RelayDNS Backend</h1>
-		<p>This page is served from the backend node.</p>
-		<p>Current time: <b>{{.Now}}</b></p>
-		<p>Hostname: <b>{{.Host}}</b></p>
-	</div>
-	<footer>relaydns demo client — served locally at {{.Addr}}</footer>
-</body>
-</html>`))
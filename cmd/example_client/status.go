@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagStatusJSON bool
+	flagStatusWeb  bool
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the state of a running relaydns-client daemon",
+	RunE:  runStatus,
+}
+
+func init() {
+	flags := statusCmd.Flags()
+	flags.BoolVar(&flagStatusJSON, "json", false, "print the raw status snapshot as JSON")
+	flags.BoolVar(&flagStatusWeb, "web", false, "open the status dashboard in a browser instead of printing")
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if flagAdminHTTP == "" {
+		return fmt.Errorf("--admin-http is empty; the daemon must be started with an admin HTTP address")
+	}
+	base := "http://" + flagAdminHTTP
+
+	if flagStatusWeb {
+		return openBrowser(base + "/status")
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(base + "/status.json")
+	if err != nil {
+		return fmt.Errorf("query %s: %w", base, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("query %s: unexpected status %d", base, resp.StatusCode)
+	}
+
+	var snap map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&snap); err != nil {
+		return fmt.Errorf("decode status: %w", err)
+	}
+
+	if flagStatusJSON {
+		out, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Printf("peer id:          %v\n", snap["peer_id"])
+	fmt.Printf("backend target:   %v\n", snap["backend_target"])
+	fmt.Printf("listen addrs:     %v\n", snap["listen_addrs"])
+	fmt.Printf("connected relays: %v\n", snap["connected_relays"])
+	fmt.Printf("last advertise:   %v\n", snap["last_advertise"])
+	fmt.Printf("advertised:       %v\n", snap["advertised"])
+	fmt.Printf("recent errors:    %v\n", snap["recent_errors"])
+	return nil
+}
+
+// openBrowser opens url in the user's default browser, mirroring what
+// `tailscale status --web` does for its own dashboard.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
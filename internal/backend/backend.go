@@ -0,0 +1,54 @@
+// Package backend implements the pluggable local HTTP backends that
+// relaydns-client advertises and proxies libp2p streams to: a demo page,
+// static file serving, and reverse-proxying to an upstream HTTP server.
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Backend is a pluggable local HTTP backend for relaydns-client. Future
+// modes (CGI, gRPC-web, unix-socket upstream) implement the same interface.
+type Backend interface {
+	// Handler returns the http.Handler this backend serves.
+	Handler() http.Handler
+	// Name identifies the backend mode, used in logs and status output.
+	Name() string
+}
+
+// Config holds the settings for every backend mode; only the fields
+// relevant to the selected Mode are used.
+type Config struct {
+	Mode string // "demo", "static", or "proxy"
+
+	// demo
+	ListenAddr string
+	Registry   *prometheus.Registry
+
+	// static
+	Dir     string
+	Index   string
+	ListDir bool
+
+	// proxy
+	Upstream     string
+	ProxyTimeout time.Duration
+}
+
+// New builds the Backend for cfg.Mode.
+func New(cfg Config) (Backend, error) {
+	switch cfg.Mode {
+	case "", "demo":
+		return newDemoBackend(cfg), nil
+	case "static":
+		return newStaticBackend(cfg)
+	case "proxy":
+		return newProxyBackend(cfg)
+	default:
+		return nil, fmt.Errorf("unknown backend mode %q", cfg.Mode)
+	}
+}
@@ -0,0 +1,93 @@
+package backend
+
+import (
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// demoBackend serves the original relaydns-client demo page: a small status
+// card rendered from pageTmpl. It's the default mode and requires no
+// configuration beyond the listen address it reports on the page.
+type demoBackend struct {
+	addr     string
+	requests *prometheus.CounterVec
+	latency  prometheus.Histogram
+}
+
+func newDemoBackend(cfg Config) *demoBackend {
+	registry := cfg.Registry
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+	}
+	factory := promauto.With(registry)
+
+	return &demoBackend{
+		addr: cfg.ListenAddr,
+		requests: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "relaydns_backend_requests_total",
+			Help: "Requests served by the demo backend, by path.",
+		}, []string{"path"}),
+		latency: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "relaydns_backend_request_seconds",
+			Help:    "Latency of requests served by the demo backend.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
+func (b *demoBackend) Name() string { return "demo" }
+
+func (b *demoBackend) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			b.requests.WithLabelValues(r.URL.Path).Inc()
+			b.latency.Observe(time.Since(start).Seconds())
+		}()
+
+		data := struct {
+			Now  string
+			Host string
+			Addr string
+		}{
+			Now:  time.Now().Format(time.RFC1123),
+			Host: r.Host,
+			Addr: b.addr,
+		}
+		_ = pageTmpl.Execute(w, data)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		b.requests.WithLabelValues(r.URL.Path).Inc()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+	return mux
+}
+
+var pageTmpl = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<title>RelayDNS Backend</title>
+	<style>
+		body { font-family: sans-serif; background: #f9f9f9; padding: 40px; }
+		h1 { color: #333; }
+		footer { margin-top: 40px; color: #666; font-size: 0.9em; }
+		.card { background: white; border-radius: 12px; padding: 24px; box-shadow: 0 2px 6px rgba(0,0,0,0.1); }
+	</style>
+</head>
+<body>
+	<div class="card">
+		<h1>🚀 RelayDNS Backend</h1>
+		<p>This page is served from the backend node.</p>
+		<p>Current time: <b>{{.Now}}</b></p>
+		<p>Hostname: <b>{{.Host}}</b></p>
+	</div>
+	<footer>relaydns demo client — served locally at {{.Addr}}</footer>
+</body>
+</html>`))
@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+)
+
+// proxyBackend reverse-proxies to an upstream HTTP server. httputil.ReverseProxy
+// already passes through WebSocket upgrade requests (it hijacks the
+// connection on "Connection: Upgrade"), so no extra handling is needed here.
+type proxyBackend struct {
+	upstream *url.URL
+	rp       *httputil.ReverseProxy
+}
+
+func newProxyBackend(cfg Config) (*proxyBackend, error) {
+	if cfg.Upstream == "" {
+		return nil, fmt.Errorf("proxy backend: --backend-upstream is required")
+	}
+	upstream, err := url.Parse(cfg.Upstream)
+	if err != nil {
+		return nil, fmt.Errorf("proxy backend: parse upstream: %w", err)
+	}
+
+	timeout := cfg.ProxyTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	rp := httputil.NewSingleHostReverseProxy(upstream)
+	rp.Transport = &http.Transport{
+		ResponseHeaderTimeout: timeout,
+	}
+	baseDirector := rp.Director
+	rp.Director = func(r *http.Request) {
+		baseDirector(r)
+		r.Header.Set("X-Forwarded-Host", r.Host)
+		r.Host = upstream.Host
+	}
+
+	return &proxyBackend{upstream: upstream, rp: rp}, nil
+}
+
+func (b *proxyBackend) Name() string { return "proxy" }
+
+func (b *proxyBackend) Handler() http.Handler { return b.rp }
@@ -0,0 +1,43 @@
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxyBackendRewritesHost(t *testing.T) {
+	var gotHost, gotForwardedHost string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		gotForwardedHost = r.Header.Get("X-Forwarded-Host")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	b, err := newProxyBackend(Config{Upstream: upstream.URL})
+	if err != nil {
+		t.Fatalf("newProxyBackend: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/path", nil)
+	req.Host = "client.example"
+	b.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if gotHost != upstream.Listener.Addr().String() {
+		t.Errorf("upstream saw Host = %q, want %q", gotHost, upstream.Listener.Addr().String())
+	}
+	if gotForwardedHost != "client.example" {
+		t.Errorf("X-Forwarded-Host = %q, want %q", gotForwardedHost, "client.example")
+	}
+}
+
+func TestNewProxyBackendRequiresUpstream(t *testing.T) {
+	if _, err := newProxyBackend(Config{}); err == nil {
+		t.Error("expected an error when --backend-upstream is empty")
+	}
+}
@@ -0,0 +1,83 @@
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// staticBackend serves a directory via http.FileServer, optionally
+// disabling directory listings.
+type staticBackend struct {
+	dir     string
+	index   string
+	listDir bool
+}
+
+func newStaticBackend(cfg Config) (*staticBackend, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("static backend: --backend-dir is required")
+	}
+	if fi, err := os.Stat(cfg.Dir); err != nil {
+		return nil, fmt.Errorf("static backend: %w", err)
+	} else if !fi.IsDir() {
+		return nil, fmt.Errorf("static backend: %q is not a directory", cfg.Dir)
+	}
+
+	index := cfg.Index
+	if index == "" {
+		index = "index.html"
+	}
+
+	return &staticBackend{dir: cfg.Dir, index: index, listDir: cfg.ListDir}, nil
+}
+
+func (b *staticBackend) Name() string { return "static" }
+
+func (b *staticBackend) Handler() http.Handler {
+	var fs http.FileSystem = http.Dir(b.dir)
+	if !b.listDir {
+		fs = noListingDir{fs}
+	}
+	srv := http.FileServer(fs)
+
+	if b.index == "index.html" {
+		return srv
+	}
+	// http.FileServer only auto-serves "index.html"; for a custom --index
+	// name, rewrite directory requests to it ourselves.
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			r2 := r.Clone(r.Context())
+			r2.URL.Path += b.index
+			srv.ServeHTTP(w, r2)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	})
+}
+
+// noListingFile wraps http.File so that Readdir reports no entries, so the
+// directory index http.FileServer renders for it lists nothing.
+type noListingFile struct{ http.File }
+
+func (f noListingFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, nil
+}
+
+// noListingDir wraps an http.FileSystem so directory requests still resolve
+// their index file, and a directory without one gets an empty listing page
+// instead of one showing its entries.
+type noListingDir struct{ http.FileSystem }
+
+func (fs noListingDir) Open(name string) (http.File, error) {
+	f, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if fi, err := f.Stat(); err == nil && fi.IsDir() {
+		return noListingFile{f}, nil
+	}
+	return f, nil
+}
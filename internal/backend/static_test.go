@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestStaticBackend(t *testing.T, cfg Config) *staticBackend {
+	t.Helper()
+	b, err := newStaticBackend(cfg)
+	if err != nil {
+		t.Fatalf("newStaticBackend: %v", err)
+	}
+	return b
+}
+
+func TestStaticBackendServesCustomIndex(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "home.html"), []byte("custom index"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newTestStaticBackend(t, Config{Dir: dir, Index: "home.html"})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	b.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Body.String(); got != "custom index" {
+		t.Errorf("body = %q, want %q", got, "custom index")
+	}
+}
+
+func TestStaticBackendListDirDisabled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newTestStaticBackend(t, Config{Dir: dir, ListDir: false})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	b.Handler().ServeHTTP(rec, req)
+
+	if strings.Contains(rec.Body.String(), "file.txt") {
+		t.Errorf("expected no directory listing with ListDir disabled, got body %q", rec.Body.String())
+	}
+}
+
+func TestStaticBackendListDirEnabled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newTestStaticBackend(t, Config{Dir: dir, ListDir: true})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	b.Handler().ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "file.txt") {
+		t.Errorf("expected directory listing to mention file.txt, got body %q", rec.Body.String())
+	}
+}
+
+func TestNewStaticBackendRequiresDir(t *testing.T) {
+	if _, err := newStaticBackend(Config{}); err == nil {
+		t.Error("expected an error when --backend-dir is empty")
+	}
+}
@@ -0,0 +1,134 @@
+package relaydnspb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	registerMethod     = "/relaydns.v1.ControlPlane/Register"
+	heartbeatMethod    = "/relaydns.v1.ControlPlane/Heartbeat"
+	listBackendsMethod = "/relaydns.v1.ControlPlane/ListBackends"
+)
+
+// withJSONCodec forces jsonCodec for this call, rather than relying on it
+// being the connection's (or grpc-go's) default codec.
+func withJSONCodec(opts []grpc.CallOption) []grpc.CallOption {
+	return append([]grpc.CallOption{grpc.ForceCodec(jsonCodec{})}, opts...)
+}
+
+// ControlPlaneClient is the client API for the ControlPlane service defined
+// in relaydns.proto.
+type ControlPlaneClient interface {
+	Register(ctx context.Context, in *BackendInfo, opts ...grpc.CallOption) (ControlPlane_RegisterClient, error)
+	Heartbeat(ctx context.Context, opts ...grpc.CallOption) (ControlPlane_HeartbeatClient, error)
+	ListBackends(ctx context.Context, in *Filter, opts ...grpc.CallOption) (ControlPlane_ListBackendsClient, error)
+}
+
+type controlPlaneClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewControlPlaneClient returns a ControlPlaneClient backed by cc.
+func NewControlPlaneClient(cc *grpc.ClientConn) ControlPlaneClient {
+	return &controlPlaneClient{cc: cc}
+}
+
+// ControlPlane_RegisterClient is the server-streaming response from Register.
+type ControlPlane_RegisterClient interface {
+	Recv() (*ServerEvent, error)
+	grpc.ClientStream
+}
+
+type controlPlaneRegisterClient struct {
+	grpc.ClientStream
+}
+
+func (c *controlPlaneClient) Register(ctx context.Context, in *BackendInfo, opts ...grpc.CallOption) (ControlPlane_RegisterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Register", ServerStreams: true}, registerMethod, withJSONCodec(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlPlaneRegisterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *controlPlaneRegisterClient) Recv() (*ServerEvent, error) {
+	m := new(ServerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ControlPlane_HeartbeatClient is the bidi stream returned by Heartbeat.
+type ControlPlane_HeartbeatClient interface {
+	Send(*BackendStats) error
+	Recv() (*Ack, error)
+	grpc.ClientStream
+}
+
+type controlPlaneHeartbeatClient struct {
+	grpc.ClientStream
+}
+
+func (c *controlPlaneClient) Heartbeat(ctx context.Context, opts ...grpc.CallOption) (ControlPlane_HeartbeatClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Heartbeat", ServerStreams: true, ClientStreams: true}, heartbeatMethod, withJSONCodec(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	return &controlPlaneHeartbeatClient{stream}, nil
+}
+
+func (x *controlPlaneHeartbeatClient) Send(m *BackendStats) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *controlPlaneHeartbeatClient) Recv() (*Ack, error) {
+	m := new(Ack)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ControlPlane_ListBackendsClient is the server-streaming response from
+// ListBackends.
+type ControlPlane_ListBackendsClient interface {
+	Recv() (*BackendInfo, error)
+	grpc.ClientStream
+}
+
+type controlPlaneListBackendsClient struct {
+	grpc.ClientStream
+}
+
+func (c *controlPlaneClient) ListBackends(ctx context.Context, in *Filter, opts ...grpc.CallOption) (ControlPlane_ListBackendsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "ListBackends", ServerStreams: true}, listBackendsMethod, withJSONCodec(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlPlaneListBackendsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *controlPlaneListBackendsClient) Recv() (*BackendInfo, error) {
+	m := new(BackendInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
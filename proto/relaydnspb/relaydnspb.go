@@ -0,0 +1,90 @@
+// Package relaydnspb contains the Go types for proto/relaydns.proto's
+// ControlPlane service and messages.
+//
+// These are hand-written rather than protoc-generated: the build
+// environments that run this client don't all carry a protoc toolchain, so
+// checking in real .pb.go stubs isn't reliable. The wire format is JSON
+// rather than protobuf binary (see jsonCodec below), but the Go-level API
+// (message field names, the ControlPlaneClient interface, streaming method
+// shapes) mirrors what protoc-gen-go/protoc-gen-go-grpc would produce from
+// relaydns.proto, so regenerating this package for real once protoc is
+// available should be a drop-in replacement. Keep the two in sync by hand
+// until then; `make proto` documents the intended real codegen.
+package relaydnspb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// ServerEvent_Kind mirrors the ServerEvent.Kind enum in relaydns.proto.
+type ServerEvent_Kind int32
+
+const (
+	ServerEvent_UNKNOWN      ServerEvent_Kind = 0
+	ServerEvent_REANNOUNCE   ServerEvent_Kind = 1
+	ServerEvent_SWITCH_RELAY ServerEvent_Kind = 2
+)
+
+// BackendInfo mirrors the BackendInfo message in relaydns.proto.
+type BackendInfo struct {
+	PeerId     string   `json:"peer_id"`
+	Name       string   `json:"name"`
+	Dns        string   `json:"dns"`
+	Protocol   string   `json:"protocol"`
+	Multiaddrs []string `json:"multiaddrs"`
+}
+
+// ServerEvent mirrors the ServerEvent message in relaydns.proto.
+type ServerEvent struct {
+	Kind   ServerEvent_Kind `json:"kind"`
+	Detail string           `json:"detail"`
+}
+
+// BackendStats mirrors the BackendStats message in relaydns.proto.
+type BackendStats struct {
+	PeerId      string `json:"peer_id"`
+	StreamsOpen int64  `json:"streams_open"`
+	BytesIn     int64  `json:"bytes_in"`
+	BytesOut    int64  `json:"bytes_out"`
+}
+
+// Ack mirrors the Ack message in relaydns.proto.
+type Ack struct {
+	Ok bool `json:"ok"`
+}
+
+// Filter mirrors the Filter message in relaydns.proto.
+type Filter struct {
+	NamePrefix string `json:"name_prefix"`
+	Protocol   string `json:"protocol"`
+}
+
+// jsonCodecName is the subtype this package's codec is registered under.
+// Deliberately not "proto" (grpc-go's hardcoded default codec name):
+// encoding.RegisterCodec is a process-global registry, so claiming the
+// default name here would silently break every other gRPC client/server
+// sharing this binary that expects real protobuf on the wire. Callers that
+// want this codec opt in per-call via grpc.ForceCodec, instead of it being
+// implied by the method/connection.
+const jsonCodecName = "relaydns-json"
+
+// jsonCodec implements encoding.Codec by marshaling messages as JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return jsonCodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
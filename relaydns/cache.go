@@ -0,0 +1,215 @@
+package relaydns
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// ProviderEntry is a single cached record about a remote peer: the last
+// multiaddrs it was reachable at, what it last advertised, and when it was
+// last confirmed up. A non-zero FailedAt marks a negative-cached
+// (known-unreachable) peer; its Multiaddrs keep whatever was last cached for
+// it, if anything.
+type ProviderEntry struct {
+	PeerID     string    `json:"peer_id"`
+	Multiaddrs []string  `json:"multiaddrs"`
+	Name       string    `json:"name"`
+	DNS        string    `json:"dns"`
+	Protocol   string    `json:"protocol"`
+	LastSeen   time.Time `json:"last_seen"`
+	FailedAt   time.Time `json:"failed_at,omitempty"`
+}
+
+func (e ProviderEntry) expired(positiveTTL, negativeTTL time.Duration) bool {
+	if !e.FailedAt.IsZero() {
+		return time.Since(e.FailedAt) > negativeTTL
+	}
+	return time.Since(e.LastSeen) > positiveTTL
+}
+
+// ProviderCacheConfig configures a ProviderCache.
+type ProviderCacheConfig struct {
+	TTL         time.Duration // positive TTL: how long a resolved peer stays fresh
+	NegativeTTL time.Duration // negative TTL: how long a failed-to-resolve peer is remembered as down
+	PersistPath string        // optional path to persist/load the cache as JSON across restarts
+	Refresh     func(peerID string) (ProviderEntry, error) // re-resolves a peer; called by the background refresher
+}
+
+// ProviderCache is a concurrent, TTL'd cache of peer reachability and advert
+// metadata, shared between client bootstrap (in place of a one-shot /health
+// fetch) and any server-side lookup path. Entries that fail to resolve are
+// negative-cached so repeated lookups for a dead peer don't keep re-querying
+// it every time.
+type ProviderCache struct {
+	cfg ProviderCacheConfig
+
+	mu      sync.RWMutex
+	entries map[string]ProviderEntry
+
+	done chan struct{}
+}
+
+// NewProviderCache creates a ProviderCache, loading a persisted snapshot from
+// cfg.PersistPath if present, and starts the background refresh goroutine
+// when cfg.Refresh is set.
+func NewProviderCache(cfg ProviderCacheConfig) *ProviderCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 5 * time.Minute
+	}
+	if cfg.NegativeTTL <= 0 {
+		cfg.NegativeTTL = 30 * time.Second
+	}
+
+	c := &ProviderCache{
+		cfg:     cfg,
+		entries: make(map[string]ProviderEntry),
+		done:    make(chan struct{}),
+	}
+
+	if cfg.PersistPath != "" {
+		c.load()
+	}
+	if cfg.Refresh != nil {
+		go c.refreshLoop()
+	}
+
+	return c
+}
+
+// Get returns the cached entry for peerID, if present and not expired.
+func (c *ProviderCache) Get(peerID string) (ProviderEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[peerID]
+	if !ok || e.expired(c.cfg.TTL, c.cfg.NegativeTTL) {
+		return ProviderEntry{}, false
+	}
+	return e, true
+}
+
+// Put stores or refreshes a resolved entry, stamping LastSeen and clearing
+// any prior negative-cache mark.
+func (c *ProviderCache) Put(e ProviderEntry) {
+	e.LastSeen = time.Now()
+	e.FailedAt = time.Time{}
+
+	c.mu.Lock()
+	c.entries[e.PeerID] = e
+	c.mu.Unlock()
+
+	c.persist()
+}
+
+// PutFailed negative-caches peerID, recording that it failed to resolve so
+// it isn't immediately retried.
+func (c *ProviderCache) PutFailed(peerID string) {
+	c.mu.Lock()
+	e := c.entries[peerID]
+	e.PeerID = peerID
+	e.FailedAt = time.Now()
+	c.entries[peerID] = e
+	c.mu.Unlock()
+
+	c.persist()
+}
+
+// Close stops the background refresh goroutine.
+func (c *ProviderCache) Close() {
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+}
+
+func (c *ProviderCache) refreshLoop() {
+	// Refresh at a quarter of the positive TTL so entries are re-resolved
+	// well before they'd otherwise expire.
+	interval := c.cfg.TTL / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-t.C:
+			c.refreshDue()
+		}
+	}
+}
+
+// dueForRefresh returns the peer IDs that should be re-resolved: positive
+// entries older than half their TTL (so they're refreshed before they'd
+// otherwise expire), and negative-cached entries older than their full
+// NegativeTTL (so a dead peer isn't re-queried on every refresh tick).
+func (c *ProviderCache) dueForRefresh() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	due := make([]string, 0)
+	for id, e := range c.entries {
+		if !e.FailedAt.IsZero() {
+			if time.Since(e.FailedAt) > c.cfg.NegativeTTL {
+				due = append(due, id)
+			}
+			continue
+		}
+		if time.Since(e.LastSeen) > c.cfg.TTL/2 {
+			due = append(due, id)
+		}
+	}
+	return due
+}
+
+func (c *ProviderCache) refreshDue() {
+	for _, id := range c.dueForRefresh() {
+		e, err := c.cfg.Refresh(id)
+		if err != nil {
+			c.PutFailed(id)
+			continue
+		}
+		c.Put(e)
+	}
+}
+
+func (c *ProviderCache) persist() {
+	if c.cfg.PersistPath == "" {
+		return
+	}
+	c.mu.RLock()
+	snapshot := make([]ProviderEntry, 0, len(c.entries))
+	for _, e := range c.entries {
+		snapshot = append(snapshot, e)
+	}
+	c.mu.RUnlock()
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.cfg.PersistPath, data, 0o644)
+}
+
+func (c *ProviderCache) load() {
+	data, err := os.ReadFile(c.cfg.PersistPath)
+	if err != nil {
+		return
+	}
+	var snapshot []ProviderEntry
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range snapshot {
+		c.entries[e.PeerID] = e
+	}
+}
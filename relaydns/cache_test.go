@@ -0,0 +1,96 @@
+package relaydns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProviderEntryExpired(t *testing.T) {
+	ttl := time.Minute
+	negTTL := 10 * time.Second
+
+	tests := []struct {
+		name  string
+		entry ProviderEntry
+		want  bool
+	}{
+		{"fresh positive", ProviderEntry{LastSeen: time.Now()}, false},
+		{"expired positive", ProviderEntry{LastSeen: time.Now().Add(-2 * ttl)}, true},
+		{"fresh negative", ProviderEntry{FailedAt: time.Now()}, false},
+		{"expired negative", ProviderEntry{FailedAt: time.Now().Add(-2 * negTTL)}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.entry.expired(ttl, negTTL); got != tc.want {
+				t.Errorf("expired() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProviderCachePutAndGet(t *testing.T) {
+	c := NewProviderCache(ProviderCacheConfig{TTL: time.Minute, NegativeTTL: time.Second})
+	defer c.Close()
+
+	c.Put(ProviderEntry{PeerID: "p1", Multiaddrs: []string{"/ip4/1.2.3.4/tcp/4001"}})
+
+	e, ok := c.Get("p1")
+	if !ok {
+		t.Fatal("expected entry to be present")
+	}
+	if len(e.Multiaddrs) != 1 || e.Multiaddrs[0] != "/ip4/1.2.3.4/tcp/4001" {
+		t.Errorf("unexpected multiaddrs: %v", e.Multiaddrs)
+	}
+
+	if _, ok := c.Get("missing"); ok {
+		t.Error("expected missing peer to not be found")
+	}
+}
+
+func TestProviderCachePutFailedNegativeCaching(t *testing.T) {
+	c := NewProviderCache(ProviderCacheConfig{TTL: time.Minute, NegativeTTL: 20 * time.Millisecond})
+	defer c.Close()
+
+	c.PutFailed("p1")
+
+	e, ok := c.Get("p1")
+	if !ok || e.FailedAt.IsZero() {
+		t.Fatal("expected a fresh negative-cache hit")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := c.Get("p1"); ok {
+		t.Error("expected negative-cache entry to expire after NegativeTTL")
+	}
+}
+
+func TestDueForRefreshRespectsNegativeTTL(t *testing.T) {
+	c := NewProviderCache(ProviderCacheConfig{TTL: time.Minute, NegativeTTL: time.Hour})
+	defer c.Close()
+
+	c.PutFailed("p1")
+
+	for _, id := range c.dueForRefresh() {
+		if id == "p1" {
+			t.Fatal("freshly-failed entry should not be due for refresh before NegativeTTL elapses")
+		}
+	}
+}
+
+func TestDueForRefreshRetriesAfterNegativeTTL(t *testing.T) {
+	c := NewProviderCache(ProviderCacheConfig{TTL: time.Minute, NegativeTTL: 10 * time.Millisecond})
+	defer c.Close()
+
+	c.PutFailed("p1")
+	time.Sleep(20 * time.Millisecond)
+
+	found := false
+	for _, id := range c.dueForRefresh() {
+		if id == "p1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected failed entry past NegativeTTL to be due for refresh")
+	}
+}
@@ -0,0 +1,380 @@
+package relaydns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/rs/zerolog/log"
+)
+
+// ClientConfig configures a Client: the libp2p protocol/topic it advertises
+// on, how the backend is described to relayserver, and how it discovers
+// relay/bootstrap peers.
+type ClientConfig struct {
+	Protocol       string        // libp2p protocol id used for proxied streams
+	Topic          string        // pubsub topic backend adverts are published to
+	AdvertiseEvery time.Duration // interval between adverts
+	Name           string        // backend display name
+	DNS            string        // backend DNS metadata (optional)
+	TargetTCP      string        // local host:port the backend listens on
+
+	ServerURL   string        // relayserver admin base URL, used to seed Bootstraps from /health
+	Bootstraps  []string      // multiaddrs with /p2p/ to connect/advertise through
+	HTTPTimeout time.Duration // timeout for the /health fetch
+
+	PreferQUIC  bool // prefer QUIC multiaddrs when selecting among bootstraps
+	PreferLocal bool // prefer loopback/local multiaddrs when selecting among bootstraps
+	PreferGeo   bool // rank bootstraps by geographic proximity (requires GeoIPDB)
+
+	GeoIPDB    string // path to a MaxMind GeoLite2 City mmdb, required for PreferGeo (unused when PreferGeo is false)
+	SelfCoords string // optional "lat,lon" override instead of self-IP geolocation
+
+	// PublicIPService is the third-party HTTP GET endpoint used to discover
+	// the local public IP when SelfCoords isn't set (this sends the query,
+	// and thus the operator's public IP, to that service). Empty disables
+	// the lookup; PreferGeo then requires SelfCoords to do anything.
+	PublicIPService string
+
+	AdminHTTP string // optional local admin HTTP listen address (empty disables it)
+
+	CacheTTL         time.Duration // positive TTL for the provider cache (see ProviderCache)
+	CacheNegativeTTL time.Duration // negative TTL for peers that failed to resolve
+	CachePath        string        // optional path to persist the provider cache across restarts
+
+	GRPCEndpoint string // optional relayserver gRPC address; enables the control-plane worker alongside pubsub adverts
+
+	MetricsAddr string // optional Prometheus /metrics listen address (empty disables metrics)
+	Pprof       bool   // mount net/http/pprof on MetricsAddr (requires MetricsAddr to be set)
+}
+
+// advert is the metadata published to Topic for each AdvertiseEvery tick.
+type advert struct {
+	PeerID   string `json:"peer_id"`
+	Name     string `json:"name"`
+	DNS      string `json:"dns"`
+	Protocol string `json:"protocol"`
+	Target   string `json:"target"`
+}
+
+// Client advertises a local backend over libp2p pubsub, while tracking
+// enough state about itself for operator visibility (see Snapshot). It does
+// not register a stream handler for Protocol: incoming libp2p streams are not
+// proxied to TargetTCP by Client.
+type Client struct {
+	host host.Host
+	cfg  ClientConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	ps    *pubsub.PubSub
+	topic *pubsub.Topic
+
+	mu            sync.Mutex
+	lastAdvertise time.Time
+	recentErrors  []string
+
+	cache      *ProviderCache
+	geo        *geoSelector
+	grpc       *grpcWorker
+	adminSrv   *http.Server
+	metricsSrv *http.Server
+
+	Metrics *Metrics
+
+	stateCh chan struct{}
+}
+
+// serverHealthKey is the ProviderCache key used for the relayserver's
+// resolved bootstrap multiaddrs (the server isn't itself a libp2p peer ID,
+// but the cache is keyed generically by string so this reuses it).
+const serverHealthKey = "relayserver:health"
+
+// NewClient builds a Client bound to host h, connects to cfg.Bootstraps (or
+// discovers them from cfg.ServerURL's /health endpoint when Bootstraps is
+// empty), joins cfg.Topic, and starts the background advertise loop. If
+// cfg.AdminHTTP is set, it also starts the local admin HTTP surface exposing
+// /status and /status.json.
+func NewClient(ctx context.Context, h host.Host, cfg ClientConfig) (*Client, error) {
+	if cfg.AdvertiseEvery <= 0 {
+		cfg.AdvertiseEvery = 3 * time.Second
+	}
+	if cfg.HTTPTimeout <= 0 {
+		cfg.HTTPTimeout = 3 * time.Second
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	c := &Client{
+		host:    h,
+		cfg:     cfg,
+		ctx:     cctx,
+		cancel:  cancel,
+		stateCh: make(chan struct{}, 1),
+		Metrics: NewMetrics(),
+	}
+
+	c.cache = NewProviderCache(ProviderCacheConfig{
+		TTL:         cfg.CacheTTL,
+		NegativeTTL: cfg.CacheNegativeTTL,
+		PersistPath: cfg.CachePath,
+		Refresh:     c.refreshServerHealth,
+	})
+
+	bootstraps := cfg.Bootstraps
+	if len(bootstraps) == 0 && cfg.ServerURL != "" {
+		if entry, ok := c.cache.Get(serverHealthKey); ok {
+			bootstraps = entry.Multiaddrs
+		} else if entry, err := c.refreshServerHealth(serverHealthKey); err != nil {
+			c.recordError(fmt.Errorf("fetch /health: %w", err))
+			c.cache.PutFailed(serverHealthKey)
+		} else {
+			bootstraps = entry.Multiaddrs
+			c.cache.Put(entry)
+		}
+	}
+	bootstraps = selectMultiaddrs(bootstraps, cfg)
+
+	if cfg.PreferGeo {
+		gs, err := newGeoSelector(cfg)
+		if err != nil {
+			c.recordError(fmt.Errorf("geoip: %w", err))
+		} else if gs != nil {
+			c.geo = gs
+			bootstraps = gs.rank(bootstraps)
+		}
+	}
+
+	for _, b := range bootstraps {
+		if _, err := connectMultiaddr(cctx, h, b); err != nil {
+			c.recordError(fmt.Errorf("connect %s: %w", b, err))
+		}
+	}
+	c.Metrics.RelayPeers.Set(float64(len(h.Network().Peers())))
+
+	ps, err := pubsub.NewGossipSub(cctx, h)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("new gossipsub: %w", err)
+	}
+	c.ps = ps
+
+	topic, err := ps.Join(cfg.Topic)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("join topic %q: %w", cfg.Topic, err)
+	}
+	c.topic = topic
+
+	go c.advertiseLoop()
+	c.watchConnState()
+
+	if cfg.GRPCEndpoint != "" {
+		gw, err := newGRPCWorker(c)
+		if err != nil {
+			c.recordError(fmt.Errorf("grpc worker: %w", err))
+		} else {
+			c.grpc = gw
+			go gw.run(cctx)
+		}
+	}
+
+	if cfg.AdminHTTP != "" {
+		if err := c.startAdminHTTP(cfg.AdminHTTP); err != nil {
+			c.recordError(fmt.Errorf("admin http: %w", err))
+		}
+	}
+
+	if cfg.MetricsAddr != "" {
+		srv, err := startMetricsHTTP(cfg.MetricsAddr, c.Metrics, cfg.Pprof)
+		if err != nil {
+			c.recordError(fmt.Errorf("metrics http: %w", err))
+		} else {
+			c.metricsSrv = srv
+		}
+	}
+
+	return c, nil
+}
+
+// Close shuts down the advertise loop, admin HTTP server (if any), and
+// leaves the pubsub topic.
+func (c *Client) Close() error {
+	c.cancel()
+	if c.cache != nil {
+		c.cache.Close()
+	}
+	c.geo.close()
+	if c.grpc != nil {
+		_ = c.grpc.close()
+	}
+	if c.topic != nil {
+		_ = c.topic.Close()
+	}
+	if c.adminSrv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = c.adminSrv.Shutdown(shutdownCtx)
+	}
+	if c.metricsSrv != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = c.metricsSrv.Shutdown(shutdownCtx)
+	}
+	return nil
+}
+
+func (c *Client) advertiseLoop() {
+	t := time.NewTicker(c.cfg.AdvertiseEvery)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-t.C:
+			c.advertiseOnce()
+		}
+	}
+}
+
+func (c *Client) advertiseOnce() {
+	a := advert{
+		PeerID:   c.host.ID().String(),
+		Name:     c.cfg.Name,
+		DNS:      c.cfg.DNS,
+		Protocol: c.cfg.Protocol,
+		Target:   c.cfg.TargetTCP,
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		c.recordError(fmt.Errorf("marshal advert: %w", err))
+		c.Metrics.AdvertsFailed.Inc()
+		return
+	}
+	if err := c.topic.Publish(c.ctx, data); err != nil {
+		c.recordError(fmt.Errorf("publish advert: %w", err))
+		c.Metrics.AdvertsFailed.Inc()
+		return
+	}
+	c.Metrics.AdvertsSent.Inc()
+
+	c.mu.Lock()
+	c.lastAdvertise = time.Now()
+	c.mu.Unlock()
+
+	c.notifyStateChange()
+}
+
+// stateChanged returns a channel that receives a value whenever the
+// backend's state changes: a relay peer connects or disconnects, a local
+// address changes (see watchConnState), or a fresh advert is published. The
+// grpc control-plane worker uses this to push heartbeats immediately instead
+// of waiting for AdvertiseEvery.
+func (c *Client) stateChanged() <-chan struct{} {
+	return c.stateCh
+}
+
+func (c *Client) notifyStateChange() {
+	select {
+	case c.stateCh <- struct{}{}:
+	default:
+	}
+}
+
+// watchConnState hooks libp2p connection and address-change notifications so
+// notifyStateChange fires immediately on relay up/down or address changes,
+// instead of only on the next advertiseOnce tick.
+func (c *Client) watchConnState() {
+	c.host.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(_ network.Network, _ network.Conn) {
+			c.Metrics.RelayPeers.Set(float64(len(c.host.Network().Peers())))
+			c.notifyStateChange()
+		},
+		DisconnectedF: func(_ network.Network, _ network.Conn) {
+			c.Metrics.RelayPeers.Set(float64(len(c.host.Network().Peers())))
+			c.notifyStateChange()
+		},
+	})
+
+	sub, err := c.host.EventBus().Subscribe(new(event.EvtLocalAddressesUpdated))
+	if err != nil {
+		c.recordError(fmt.Errorf("subscribe to address updates: %w", err))
+		return
+	}
+	go func() {
+		defer sub.Close()
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case _, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				c.notifyStateChange()
+			}
+		}
+	}()
+}
+
+func (c *Client) recordError(err error) {
+	log.Error().Err(err).Msg("[relaydns] client error")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recentErrors = append(c.recentErrors, fmt.Sprintf("%s: %s", time.Now().Format(time.RFC3339), err))
+	if len(c.recentErrors) > 20 {
+		c.recentErrors = c.recentErrors[len(c.recentErrors)-20:]
+	}
+}
+
+// refreshServerHealth re-fetches the relayserver's /health endpoint and
+// wraps the result as a ProviderEntry, so it can seed or refresh the
+// serverHealthKey cache entry. The peerID argument is unused (the cache's
+// generic Refresh signature is keyed by string, not just peer.ID) but kept
+// for symmetry with per-peer refreshers added on the server side.
+func (c *Client) refreshServerHealth(peerID string) (ProviderEntry, error) {
+	start := time.Now()
+	addrs, err := fetchHealthAddrs(c.ctx, c.cfg.ServerURL, c.cfg.HTTPTimeout)
+	c.Metrics.HealthFetchSecs.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return ProviderEntry{}, err
+	}
+	return ProviderEntry{PeerID: serverHealthKey, Multiaddrs: addrs}, nil
+}
+
+func fetchHealthAddrs(ctx context.Context, serverURL string, timeout time.Duration) ([]string, error) {
+	hctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(hctx, http.MethodGet, serverURL+"/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var health struct {
+		Multiaddrs []string `json:"multiaddrs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, err
+	}
+	return health.Multiaddrs, nil
+}
@@ -0,0 +1,202 @@
+package relaydns
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	geoip2 "github.com/oschwald/geoip2-golang"
+)
+
+var errNoPublicIP = errors.New("relaydns: could not determine public IP")
+
+// GeoInfo is a resolved geographic location for a candidate relay/bootstrap
+// address, surfaced on the status endpoint so operators can see which relay
+// was picked and why.
+type GeoInfo struct {
+	Addr       string  `json:"addr"`
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+	Country    string  `json:"country"`
+	Continent  string  `json:"continent"`
+	DistanceKM float64 `json:"distance_km"`
+}
+
+// geoSelector resolves multiaddrs to coordinates via a MaxMind GeoLite2 City
+// database and ranks them by great-circle distance from the local host.
+type geoSelector struct {
+	db        *geoip2.Reader
+	selfLat   float64
+	selfLon   float64
+	haveSelf  bool
+	lastPicks []GeoInfo
+}
+
+// newGeoSelector opens cfg.GeoIPDB (if set) and resolves the local host's
+// coordinates, either from cfg.SelfCoords or by looking up the public IP
+// discovered via a STUN-style lookup. Returns nil (not an error) when
+// cfg.GeoIPDB is empty, so callers can treat a nil *geoSelector as "geo
+// selection disabled".
+func newGeoSelector(cfg ClientConfig) (*geoSelector, error) {
+	if cfg.GeoIPDB == "" {
+		return nil, nil
+	}
+
+	db, err := geoip2.Open(cfg.GeoIPDB)
+	if err != nil {
+		return nil, err
+	}
+	gs := &geoSelector{db: db}
+
+	if cfg.SelfCoords != "" {
+		lat, lon, err := parseCoords(cfg.SelfCoords)
+		if err == nil {
+			gs.selfLat, gs.selfLon, gs.haveSelf = lat, lon, true
+		}
+	}
+	if !gs.haveSelf {
+		if ip, err := discoverPublicIP(cfg.HTTPTimeout, cfg.PublicIPService); err == nil {
+			if rec, err := db.City(ip); err == nil {
+				gs.selfLat = rec.Location.Latitude
+				gs.selfLon = rec.Location.Longitude
+				gs.haveSelf = true
+			}
+		}
+	}
+
+	return gs, nil
+}
+
+func (gs *geoSelector) close() {
+	if gs != nil && gs.db != nil {
+		_ = gs.db.Close()
+	}
+}
+
+// rank reorders addrs by ascending distance from the local host. Addresses
+// that can't be geo-resolved (no DB, lookup failure, non-IP transport) are
+// appended at the end, preserving their relative input order.
+func (gs *geoSelector) rank(addrs []string) []string {
+	if gs == nil || gs.db == nil || !gs.haveSelf {
+		return addrs
+	}
+
+	type scored struct {
+		addr string
+		geo  GeoInfo
+		ok   bool
+	}
+	items := make([]scored, len(addrs))
+	for i, a := range addrs {
+		geo, ok := gs.resolve(a)
+		items[i] = scored{addr: a, geo: geo, ok: ok}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ok != items[j].ok {
+			return items[i].ok // resolved addrs sort before unresolved ones
+		}
+		if !items[i].ok {
+			return false // preserve input order among unresolved addrs
+		}
+		return items[i].geo.DistanceKM < items[j].geo.DistanceKM
+	})
+
+	ranked := make([]string, len(items))
+	picks := make([]GeoInfo, 0, len(items))
+	for i, it := range items {
+		ranked[i] = it.addr
+		if it.ok {
+			picks = append(picks, it.geo)
+		}
+	}
+	gs.lastPicks = picks
+	return ranked
+}
+
+func (gs *geoSelector) resolve(addr string) (GeoInfo, bool) {
+	ip := ipFromMultiaddr(addr)
+	if ip == nil {
+		return GeoInfo{}, false
+	}
+	rec, err := gs.db.City(ip)
+	if err != nil {
+		return GeoInfo{}, false
+	}
+	lat, lon := rec.Location.Latitude, rec.Location.Longitude
+	return GeoInfo{
+		Addr:       addr,
+		Lat:        lat,
+		Lon:        lon,
+		Country:    rec.Country.IsoCode,
+		Continent:  rec.Continent.Code,
+		DistanceKM: haversineKM(gs.selfLat, gs.selfLon, lat, lon),
+	}, true
+}
+
+// ipFromMultiaddr extracts the IPv4/IPv6 literal from a /ip4/.../ip6/...
+// multiaddr prefix, without pulling in the full multiaddr parser (loopback
+// and private addresses resolve fine via net.ParseIP and just won't find a
+// useful GeoIP record).
+func ipFromMultiaddr(addr string) net.IP {
+	parts := strings.Split(strings.TrimPrefix(addr, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "ip4" || parts[i] == "ip6" {
+			return net.ParseIP(parts[i+1])
+		}
+	}
+	return nil
+}
+
+func haversineKM(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKM = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return earthRadiusKM * c
+}
+
+func parseCoords(s string) (lat, lon float64, err error) {
+	_, err = fmt.Sscanf(s, "%f,%f", &lat, &lon)
+	return lat, lon, err
+}
+
+// discoverPublicIP fetches the caller's public IP from serviceURL, a simple
+// external echo service standing in for a proper STUN lookup. This sends an
+// HTTP GET (and thus the caller's public IP) to a third party, so it's only
+// used as a fallback when --self-coords isn't set, and serviceURL is
+// operator-configurable (empty disables the lookup entirely).
+func discoverPublicIP(timeout time.Duration, serviceURL string) (net.IP, error) {
+	if serviceURL == "" {
+		return nil, errNoPublicIP
+	}
+	if timeout <= 0 {
+		timeout = 3 * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(serviceURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, errNoPublicIP
+	}
+	return ip, nil
+}
@@ -0,0 +1,56 @@
+package relaydns
+
+import "testing"
+
+func TestHaversineKMSameLocation(t *testing.T) {
+	if d := haversineKM(37.7749, -122.4194, 37.7749, -122.4194); d != 0 {
+		t.Errorf("expected 0 distance for identical coords, got %v", d)
+	}
+}
+
+func TestHaversineKMKnownDistance(t *testing.T) {
+	// San Francisco to New York is roughly 4130km great-circle.
+	d := haversineKM(37.7749, -122.4194, 40.7128, -74.0060)
+	if d < 4000 || d > 4300 {
+		t.Errorf("expected ~4130km SF-NYC, got %v", d)
+	}
+}
+
+func TestIPFromMultiaddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"/ip4/1.2.3.4/tcp/4001", "1.2.3.4"},
+		{"/ip6/::1/tcp/4001", "::1"},
+		{"/dns4/example.com/tcp/4001", ""},
+	}
+	for _, tc := range tests {
+		ip := ipFromMultiaddr(tc.addr)
+		if tc.want == "" {
+			if ip != nil {
+				t.Errorf("ipFromMultiaddr(%q) = %v, want nil", tc.addr, ip)
+			}
+			continue
+		}
+		if ip == nil || ip.String() != tc.want {
+			t.Errorf("ipFromMultiaddr(%q) = %v, want %v", tc.addr, ip, tc.want)
+		}
+	}
+}
+
+func TestParseCoords(t *testing.T) {
+	lat, lon, err := parseCoords("37.7749,-122.4194")
+	if err != nil {
+		t.Fatalf("parseCoords: %v", err)
+	}
+	if lat != 37.7749 || lon != -122.4194 {
+		t.Errorf("parseCoords = (%v, %v), want (37.7749, -122.4194)", lat, lon)
+	}
+}
+
+func TestDiscoverPublicIPDisabledWhenServiceEmpty(t *testing.T) {
+	if _, err := discoverPublicIP(0, ""); err == nil {
+		t.Error("expected an error when the public IP service is disabled")
+	}
+}
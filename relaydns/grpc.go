@@ -0,0 +1,132 @@
+package relaydns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	relaydnspb "github.com/gosuda/relaydns/proto/relaydnspb"
+)
+
+// grpcWorker maintains the gRPC control-plane connection to GRPCEndpoint: it
+// registers the backend, pushes heartbeats on state changes (carrying only
+// the peer ID; stream and byte counts are sent as zero, see backendStats),
+// and handles server-initiated events as they arrive. A re-announce event
+// publishes an advert at once; a relay switch event is logged and recorded
+// as an error, and the relay connections are left unchanged. It
+// supplements, and can eventually replace, the pubsub advertise loop for
+// deployments large enough that periodic polling doesn't scale.
+type grpcWorker struct {
+	c    *Client
+	conn *grpc.ClientConn
+}
+
+func newGRPCWorker(c *Client) (*grpcWorker, error) {
+	conn, err := grpc.NewClient(c.cfg.GRPCEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", c.cfg.GRPCEndpoint, err)
+	}
+	return &grpcWorker{c: c, conn: conn}, nil
+}
+
+func (w *grpcWorker) run(ctx context.Context) {
+	client := relaydnspb.NewControlPlaneClient(w.conn)
+
+	for ctx.Err() == nil {
+		if err := w.registerAndServe(ctx, client); err != nil {
+			w.c.recordError(fmt.Errorf("grpc control plane: %w", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(2 * time.Second):
+			}
+		}
+	}
+}
+
+func (w *grpcWorker) registerAndServe(ctx context.Context, client relaydnspb.ControlPlaneClient) error {
+	info := w.c.backendInfo()
+	stream, err := client.Register(ctx, info)
+	if err != nil {
+		return fmt.Errorf("register: %w", err)
+	}
+
+	heartbeats, err := client.Heartbeat(ctx)
+	if err != nil {
+		return fmt.Errorf("heartbeat: %w", err)
+	}
+	go w.pushHeartbeats(ctx, heartbeats)
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("recv server event: %w", err)
+		}
+		w.handleEvent(ev)
+	}
+}
+
+// pushHeartbeats sends a BackendStats message whenever the backend's state
+// changes (relay connect/disconnect, local address changes, or a fresh
+// advert — see watchConnState and advertiseOnce) instead of waiting for
+// AdvertiseEvery, so the server hears of a change immediately. The message
+// holds only the peer ID: new listen addresses are not sent, and the server
+// gets the backend's multiaddrs only in the BackendInfo sent at Register.
+func (w *grpcWorker) pushHeartbeats(ctx context.Context, stream relaydnspb.ControlPlane_HeartbeatClient) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.c.stateChanged():
+			stats := w.c.backendStats()
+			if err := stream.Send(stats); err != nil {
+				w.c.recordError(fmt.Errorf("send heartbeat: %w", err))
+				return
+			}
+			if _, err := stream.Recv(); err != nil {
+				w.c.recordError(fmt.Errorf("recv heartbeat ack: %w", err))
+				return
+			}
+		}
+	}
+}
+
+func (w *grpcWorker) handleEvent(ev *relaydnspb.ServerEvent) {
+	switch ev.Kind {
+	case relaydnspb.ServerEvent_REANNOUNCE:
+		log.Info().Msg("[relaydns] server requested re-announce")
+		w.c.advertiseOnce()
+	case relaydnspb.ServerEvent_SWITCH_RELAY:
+		log.Info().Str("detail", ev.Detail).Msg("[relaydns] server requested relay switch")
+		w.c.recordError(fmt.Errorf("relay switch requested (%s) but not yet implemented", ev.Detail))
+	default:
+		log.Warn().Msgf("[relaydns] unknown server event: %v", ev.Kind)
+	}
+}
+
+func (w *grpcWorker) close() error {
+	return w.conn.Close()
+}
+
+func (c *Client) backendInfo() *relaydnspb.BackendInfo {
+	snap := c.Snapshot()
+	return &relaydnspb.BackendInfo{
+		PeerId:     snap.PeerID,
+		Name:       snap.Advertised.Name,
+		Dns:        snap.Advertised.DNS,
+		Protocol:   snap.Advertised.Protocol,
+		Multiaddrs: snap.ListenAddrs,
+	}
+}
+
+func (c *Client) backendStats() *relaydnspb.BackendStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &relaydnspb.BackendStats{
+		PeerId: c.host.ID().String(),
+	}
+}
@@ -0,0 +1,37 @@
+// Package relaydns implements the relay-assisted libp2p backend advertiser
+// used by relaydns-client, plus the shared types for talking to relayserver.
+package relaydns
+
+import (
+	"context"
+	"strconv"
+
+	libp2p "github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+)
+
+// MakeHost constructs a libp2p host listening on the given TCP/QUIC port (0
+// picks a random free port). When relay is true, the host enables circuit
+// relay (v2) client support and hole-punching so it can be reached behind
+// NAT via a relayserver-operated relay.
+func MakeHost(ctx context.Context, port int, relay bool) (host.Host, error) {
+	p := strconv.Itoa(port)
+	opts := []libp2p.Option{
+		libp2p.ListenAddrStrings(
+			"/ip4/0.0.0.0/tcp/"+p,
+			"/ip4/0.0.0.0/udp/"+p+"/quic-v1",
+		),
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.EnableNATService(),
+	}
+	if relay {
+		opts = append(opts, libp2p.EnableRelay(), libp2p.EnableHolePunching())
+	}
+
+	h, err := libp2p.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
@@ -0,0 +1,75 @@
+package relaydns
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus collectors for a Client. It's exported so
+// cmd/example_client can pass Registry to internal/backend, whose demo
+// backend registers its own request counter/latency histogram against the
+// same registry.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	AdvertsSent     prometheus.Counter
+	AdvertsFailed   prometheus.Counter
+	RelayPeers      prometheus.Gauge
+	HealthFetchSecs prometheus.Histogram
+}
+
+// NewMetrics registers and returns the relaydns client metrics on a fresh
+// registry.
+func NewMetrics() *Metrics {
+	reg := prometheus.NewRegistry()
+	factory := promauto.With(reg)
+
+	return &Metrics{
+		Registry: reg,
+
+		AdvertsSent: factory.NewCounter(prometheus.CounterOpts{
+			Name: "relaydns_adverts_sent_total",
+			Help: "Number of backend adverts successfully published.",
+		}),
+		AdvertsFailed: factory.NewCounter(prometheus.CounterOpts{
+			Name: "relaydns_adverts_failed_total",
+			Help: "Number of backend adverts that failed to publish.",
+		}),
+		RelayPeers: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "relaydns_relay_peers",
+			Help: "Current number of connected relay peers.",
+		}),
+		HealthFetchSecs: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "relaydns_health_fetch_seconds",
+			Help:    "Latency of relayserver /health fetches.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+}
+
+// startMetricsHTTP starts the Prometheus /metrics endpoint at addr, and
+// additionally mounts net/http/pprof when pprofEnabled is true.
+func startMetricsHTTP(addr string, m *Metrics, pprofEnabled bool) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{}))
+
+	if pprofEnabled {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	ln, err := newListener(addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Handler: mux}
+	go func() { _ = srv.Serve(ln) }()
+	return srv, nil
+}
@@ -0,0 +1,8 @@
+package relaydns
+
+import "net"
+
+// newListener opens a TCP listener for a local admin/metrics endpoint.
+func newListener(addr string) (net.Listener, error) {
+	return net.Listen("tcp", addr)
+}
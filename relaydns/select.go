@@ -0,0 +1,59 @@
+package relaydns
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// selectMultiaddrs orders candidate bootstrap multiaddrs according to cfg's
+// preferences (PreferQUIC, PreferLocal), stable-sorting so ties preserve the
+// input order.
+func selectMultiaddrs(addrs []string, cfg ClientConfig) []string {
+	ranked := make([]string, len(addrs))
+	copy(ranked, addrs)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return addrScore(ranked[i], cfg) > addrScore(ranked[j], cfg)
+	})
+	return ranked
+}
+
+func addrScore(addr string, cfg ClientConfig) int {
+	score := 0
+	if cfg.PreferQUIC && strings.Contains(addr, "/quic") {
+		score++
+	}
+	if cfg.PreferLocal && isLocalMultiaddr(addr) {
+		score++
+	}
+	return score
+}
+
+func isLocalMultiaddr(addr string) bool {
+	return strings.Contains(addr, "/ip4/127.") ||
+		strings.Contains(addr, "/ip4/10.") ||
+		strings.Contains(addr, "/ip4/192.168.") ||
+		strings.Contains(addr, "/ip6/::1")
+}
+
+// connectMultiaddr parses a multiaddr (with a trailing /p2p/<id>), connects
+// host h to it, and returns the resolved peer ID.
+func connectMultiaddr(ctx context.Context, h host.Host, addr string) (peer.ID, error) {
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return "", err
+	}
+	pi, err := peer.AddrInfoFromP2pAddr(maddr)
+	if err != nil {
+		return "", err
+	}
+	if err := h.Connect(ctx, *pi); err != nil {
+		return "", err
+	}
+	return pi.ID, nil
+}
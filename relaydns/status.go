@@ -0,0 +1,136 @@
+package relaydns
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// AdvertisedMetadata describes what a Client is currently telling
+// relayserver about its backend.
+type AdvertisedMetadata struct {
+	Name     string `json:"name"`
+	DNS      string `json:"dns"`
+	Protocol string `json:"protocol"`
+	Topic    string `json:"topic"`
+}
+
+// Snapshot is a point-in-time view of a Client's state, suitable for
+// operator-facing status output (JSON or HTML).
+type Snapshot struct {
+	BackendTarget   string             `json:"backend_target"`
+	PeerID          string             `json:"peer_id"`
+	ListenAddrs     []string           `json:"listen_addrs"`
+	ConnectedRelays []string           `json:"connected_relays"`
+	LastAdvertise   time.Time          `json:"last_advertise"`
+	Advertised      AdvertisedMetadata `json:"advertised"`
+	RecentErrors    []string           `json:"recent_errors"`
+	GeoPicks        []GeoInfo          `json:"geo_picks,omitempty"`
+}
+
+// Snapshot returns the Client's current state for status reporting.
+func (c *Client) Snapshot() Snapshot {
+	// ConnectedRelays is read live from the libp2p network rather than a
+	// cached slice, so a relay that drops (or a later reconnect) shows up
+	// immediately instead of the connectivity at bootstrap time.
+	peers := c.host.Network().Peers()
+	relays := make([]string, len(peers))
+	for i, p := range peers {
+		relays[i] = p.String()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	errs := make([]string, len(c.recentErrors))
+	copy(errs, c.recentErrors)
+
+	addrs := c.host.Addrs()
+	listen := make([]string, len(addrs))
+	for i, a := range addrs {
+		listen[i] = a.String()
+	}
+
+	return Snapshot{
+		BackendTarget:   c.cfg.TargetTCP,
+		PeerID:          c.host.ID().String(),
+		ListenAddrs:     listen,
+		ConnectedRelays: relays,
+		LastAdvertise:   c.lastAdvertise,
+		Advertised: AdvertisedMetadata{
+			Name:     c.cfg.Name,
+			DNS:      c.cfg.DNS,
+			Protocol: c.cfg.Protocol,
+			Topic:    c.cfg.Topic,
+		},
+		RecentErrors: errs,
+		GeoPicks:     c.geoPicks(),
+	}
+}
+
+// geoPicks returns the resolved locations used by the last geo-aware relay
+// selection: nil when geo selection isn't active or never ran a ranking,
+// and empty when a ranking ran but no address resolved.
+func (c *Client) geoPicks() []GeoInfo {
+	if c.geo == nil {
+		return nil
+	}
+	return c.geo.lastPicks
+}
+
+// startAdminHTTP starts the local admin HTTP surface at addr, exposing
+// /status (HTML dashboard) and /status.json (machine-readable snapshot).
+// It is intentionally unauthenticated and meant to be bound to loopback.
+func (c *Client) startAdminHTTP(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(c.Snapshot())
+	})
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = statusPageTmpl.Execute(w, c.Snapshot())
+	})
+
+	ln, err := newListener(addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: mux}
+	c.adminSrv = srv
+	go func() { _ = srv.Serve(ln) }()
+	return nil
+}
+
+var statusPageTmpl = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="UTF-8">
+	<title>RelayDNS Client Status</title>
+	<style>
+		body { font-family: sans-serif; background: #f9f9f9; padding: 40px; }
+		h1 { color: #333; }
+		table { border-collapse: collapse; }
+		td, th { text-align: left; padding: 4px 12px 4px 0; vertical-align: top; }
+		.card { background: white; border-radius: 12px; padding: 24px; box-shadow: 0 2px 6px rgba(0,0,0,0.1); }
+	</style>
+</head>
+<body>
+	<div class="card">
+		<h1>RelayDNS Client Status</h1>
+		<table>
+			<tr><th>Peer ID</th><td>{{.PeerID}}</td></tr>
+			<tr><th>Backend target</th><td>{{.BackendTarget}}</td></tr>
+			<tr><th>Listen addrs</th><td>{{range .ListenAddrs}}{{.}}<br>{{end}}</td></tr>
+			<tr><th>Connected relays</th><td>{{range .ConnectedRelays}}{{.}}<br>{{end}}</td></tr>
+			<tr><th>Last advertise</th><td>{{.LastAdvertise}}</td></tr>
+			<tr><th>Advertised name</th><td>{{.Advertised.Name}}</td></tr>
+			<tr><th>Advertised DNS</th><td>{{.Advertised.DNS}}</td></tr>
+			<tr><th>Protocol / topic</th><td>{{.Advertised.Protocol}} / {{.Advertised.Topic}}</td></tr>
+			<tr><th>Recent errors</th><td>{{range .RecentErrors}}{{.}}<br>{{end}}</td></tr>
+			<tr><th>Geo picks</th><td>{{range .GeoPicks}}{{.Addr}} ({{.Country}}, {{.DistanceKM}}km)<br>{{end}}</td></tr>
+		</table>
+	</div>
+</body>
+</html>`))